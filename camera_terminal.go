@@ -0,0 +1,569 @@
+package uvc
+
+/*
+#include <libuvc-cgo.h>
+*/
+import "C"
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// UVC 1.5 Camera Terminal control selectors (UVC spec Table 4-7) that
+// predate libuvc, which only wraps the base UVC 1.1 Camera Terminal
+// controls. These are issued as raw class-specific requests against the
+// Camera Terminal's entity ID, the same way ExtensionUnit.Get/Set already
+// do for vendor controls libuvc has no named wrapper for.
+const (
+	ctWindowControl           = 0x13
+	ctRegionOfInterestControl = 0x14
+)
+
+// cameraTerminalID finds the bTerminalID of the VideoControl interface's
+// Camera Terminal (the ITT_CAMERA input terminal), the entity the raw
+// Digital Window / ROI requests below must address.
+func cameraTerminalID(handle *C.uvc_device_handle_t) (uint8, error) {
+	for it := handle.info.ctrl_if.input_terminals; it != nil; it = it.next {
+		if uint16(it.wTerminalType) == uint16(C.UVC_ITT_CAMERA) {
+			return uint8(it.bTerminalID), nil
+		}
+	}
+	return 0, ErrNotSupported
+}
+
+// ctClassRequest issues a class-specific request against the Camera
+// Terminal entity via libuvc's generic uvc_get_ctrl/uvc_set_ctrl, for
+// Camera Terminal controls (like Digital Window and ROI) that libuvc has
+// no dedicated uvc_get_*/uvc_set_* wrapper for.
+func ctClassRequest(handle *C.uvc_device_handle_t, selector uint8, req ControlRequest, buf []byte, set bool) error {
+	if len(buf) == 0 {
+		return ErrInvalidParam
+	}
+
+	unitID, err := cameraTerminalID(handle)
+	if err != nil {
+		return err
+	}
+
+	if set {
+		r := C.uvc_set_ctrl(handle, C.uchar(unitID), C.uchar(selector),
+			unsafe.Pointer(&buf[0]), C.int(len(buf)))
+		if r < 0 {
+			return newError(ErrorType(r))
+		}
+		return nil
+	}
+
+	r := C.uvc_get_ctrl(handle, C.uchar(unitID), C.uchar(selector),
+		unsafe.Pointer(&buf[0]), C.int(len(buf)), C.enum_uvc_req_code(req))
+	if r < 0 {
+		return newError(ErrorType(r))
+	}
+	return nil
+}
+
+// PanTilt is the paired absolute pan/tilt position reported and accepted by
+// the Camera Terminal's CT_PANTILT_ABSOLUTE_CONTROL, which packs both axes
+// into a single class-specific request.
+type PanTilt struct {
+	Pan  int32
+	Tilt int32
+}
+
+func (dev *Device) GetScanningMode() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_scanning_mode(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetScanningMode(mode uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_scanning_mode(dev.handle, C.uchar(mode))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetExposureAbsolute(req ControlRequest) (uint32, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uint
+	r := C.uvc_get_exposure_abs(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint32(v), nil
+}
+
+func (dev *Device) SetExposureAbsolute(exposure uint32) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_exposure_abs(dev.handle, C.uint(exposure))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetExposureAbsoluteRange() (min, max, def, res uint32, err error) {
+	if min, err = dev.GetExposureAbsolute(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetExposureAbsolute(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetExposureAbsolute(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetExposureAbsolute(ReqRes)
+	return
+}
+
+func (dev *Device) GetFocusAbsolute(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_focus_abs(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetFocusAbsolute(focus uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_focus_abs(dev.handle, C.ushort(focus))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetFocusAbsoluteRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetFocusAbsolute(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetFocusAbsolute(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetFocusAbsolute(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetFocusAbsolute(ReqRes)
+	return
+}
+
+// SetFocusRelative nudges focus by focusRel steps at the given speed.
+// A focusRel of zero stops any focus movement already in progress.
+func (dev *Device) SetFocusRelative(focusRel int8, speed uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_focus_rel(dev.handle, C.schar(focusRel), C.uchar(speed))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetFocusAuto() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_focus_auto(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetFocusAuto(auto uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_focus_auto(dev.handle, C.uchar(auto))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetIrisAbsolute(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_iris_abs(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetIrisAbsolute(iris uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_iris_abs(dev.handle, C.ushort(iris))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetIrisAbsoluteRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetIrisAbsolute(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetIrisAbsolute(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetIrisAbsolute(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetIrisAbsolute(ReqRes)
+	return
+}
+
+// SetIrisRelative steps the iris open (1) or closed (-1); zero is a no-op.
+func (dev *Device) SetIrisRelative(irisRel int8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_iris_rel(dev.handle, C.schar(irisRel))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetZoomAbsolute(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_zoom_abs(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetZoomAbsolute(zoom uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_zoom_abs(dev.handle, C.ushort(zoom))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetZoomAbsoluteRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetZoomAbsolute(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetZoomAbsolute(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetZoomAbsolute(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetZoomAbsolute(ReqRes)
+	return
+}
+
+// SetZoomRelative drives the zoom motor: zoomRel is -1 (tele), 0 (stop), or
+// 1 (wide); digitalZoom enables the digital zoom extension at the end of the
+// optical range; speed sets the motor speed.
+func (dev *Device) SetZoomRelative(zoomRel int8, digitalZoom uint8, speed uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_zoom_rel(dev.handle, C.schar(zoomRel), C.uchar(digitalZoom), C.uchar(speed))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetPanTiltAbsolute(req ControlRequest) (PanTilt, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return PanTilt{}, ErrDeviceClosed
+	}
+
+	var pan, tilt C.int
+	r := C.uvc_get_pantilt_abs(dev.handle, &pan, &tilt, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return PanTilt{}, err
+	}
+	return PanTilt{Pan: int32(pan), Tilt: int32(tilt)}, nil
+}
+
+func (dev *Device) SetPanTiltAbsolute(pt PanTilt) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_pantilt_abs(dev.handle, C.int(pt.Pan), C.int(pt.Tilt))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetPanTiltAbsoluteRange() (min, max, def, res PanTilt, err error) {
+	if min, err = dev.GetPanTiltAbsolute(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetPanTiltAbsolute(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetPanTiltAbsolute(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetPanTiltAbsolute(ReqRes)
+	return
+}
+
+// SetPanTiltRelative nudges pan/tilt by the given steps at the given speeds;
+// zero stops movement on that axis.
+func (dev *Device) SetPanTiltRelative(panRel int8, panSpeed uint8, tiltRel int8, tiltSpeed uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_pantilt_rel(dev.handle, C.schar(panRel), C.uchar(panSpeed), C.schar(tiltRel), C.uchar(tiltSpeed))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetRollAbsolute(req ControlRequest) (int16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.short
+	r := C.uvc_get_roll_abs(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return int16(v), nil
+}
+
+func (dev *Device) SetRollAbsolute(roll int16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_roll_abs(dev.handle, C.short(roll))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetRollAbsoluteRange() (min, max, def, res int16, err error) {
+	if min, err = dev.GetRollAbsolute(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetRollAbsolute(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetRollAbsolute(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetRollAbsolute(ReqRes)
+	return
+}
+
+// SetRollRelative rolls the image by rollRel steps at the given speed.
+func (dev *Device) SetRollRelative(rollRel int8, speed uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_roll_rel(dev.handle, C.schar(rollRel), C.uchar(speed))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetPrivacy() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_privacy(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetPrivacy(shutter uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_privacy(dev.handle, C.uchar(shutter))
+	return newError(ErrorType(r))
+}
+
+// DigitalWindow is the UVC 1.5 CT_DIGITAL_WINDOW_CONTROL rectangle, expressed
+// as an offset from the top-left of the sensor's native resolution.
+type DigitalWindow struct {
+	Top    int16
+	Left   int16
+	Bottom int16
+	Right  int16
+}
+
+func (dev *Device) GetDigitalWindow(req ControlRequest) (DigitalWindow, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return DigitalWindow{}, ErrDeviceClosed
+	}
+
+	buf := make([]byte, 8)
+	if err := ctClassRequest(dev.handle, ctWindowControl, req, buf, false); err != nil {
+		return DigitalWindow{}, err
+	}
+	return DigitalWindow{
+		Top:    int16(binary.LittleEndian.Uint16(buf[0:2])),
+		Left:   int16(binary.LittleEndian.Uint16(buf[2:4])),
+		Bottom: int16(binary.LittleEndian.Uint16(buf[4:6])),
+		Right:  int16(binary.LittleEndian.Uint16(buf[6:8])),
+	}, nil
+}
+
+func (dev *Device) SetDigitalWindow(w DigitalWindow) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint16(buf[0:2], uint16(w.Top))
+	binary.LittleEndian.PutUint16(buf[2:4], uint16(w.Left))
+	binary.LittleEndian.PutUint16(buf[4:6], uint16(w.Bottom))
+	binary.LittleEndian.PutUint16(buf[6:8], uint16(w.Right))
+	return ctClassRequest(dev.handle, ctWindowControl, ReqCur, buf, true)
+}
+
+// RegionOfInterest is the UVC 1.5 CT_REGION_OF_INTEREST_CONTROL rectangle
+// plus the bitmask of auto-controls (AE, AWB, AF, ...) it should drive.
+type RegionOfInterest struct {
+	Top          int16
+	Left         int16
+	Bottom       int16
+	Right        int16
+	AutoControls uint16
+}
+
+func (dev *Device) GetRegionOfInterest(req ControlRequest) (RegionOfInterest, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return RegionOfInterest{}, ErrDeviceClosed
+	}
+
+	buf := make([]byte, 10)
+	if err := ctClassRequest(dev.handle, ctRegionOfInterestControl, req, buf, false); err != nil {
+		return RegionOfInterest{}, err
+	}
+	return RegionOfInterest{
+		Top:          int16(binary.LittleEndian.Uint16(buf[0:2])),
+		Left:         int16(binary.LittleEndian.Uint16(buf[2:4])),
+		Bottom:       int16(binary.LittleEndian.Uint16(buf[4:6])),
+		Right:        int16(binary.LittleEndian.Uint16(buf[6:8])),
+		AutoControls: binary.LittleEndian.Uint16(buf[8:10]),
+	}, nil
+}
+
+func (dev *Device) SetRegionOfInterest(roi RegionOfInterest) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	buf := make([]byte, 10)
+	binary.LittleEndian.PutUint16(buf[0:2], uint16(roi.Top))
+	binary.LittleEndian.PutUint16(buf[2:4], uint16(roi.Left))
+	binary.LittleEndian.PutUint16(buf[4:6], uint16(roi.Bottom))
+	binary.LittleEndian.PutUint16(buf[6:8], uint16(roi.Right))
+	binary.LittleEndian.PutUint16(buf[8:10], roi.AutoControls)
+	return ctClassRequest(dev.handle, ctRegionOfInterestControl, ReqCur, buf, true)
+}
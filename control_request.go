@@ -0,0 +1,20 @@
+package uvc
+
+/*
+#include <libuvc-cgo.h>
+*/
+import "C"
+
+// ControlRequest identifies which bRequest variant (UVC_GET_CUR, UVC_GET_MIN, ...)
+// a class-specific control request should use.
+type ControlRequest C.enum_uvc_req_code
+
+const (
+	ReqCur  ControlRequest = C.UVC_GET_CUR
+	ReqMin  ControlRequest = C.UVC_GET_MIN
+	ReqMax  ControlRequest = C.UVC_GET_MAX
+	ReqRes  ControlRequest = C.UVC_GET_RES
+	ReqLen  ControlRequest = C.UVC_GET_LEN
+	ReqInfo ControlRequest = C.UVC_GET_INFO
+	ReqDef  ControlRequest = C.UVC_GET_DEF
+)
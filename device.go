@@ -34,6 +34,8 @@ const (
 var (
 	ErrDeviceClosed   = errors.New("device closed")
 	ErrDeviceNotFound = errors.New("device not found")
+	ErrInvalidParam   = errors.New("invalid parameter")
+	ErrNotSupported   = errors.New("not supported")
 )
 
 // VideoStreaming interface descriptor subtype.
@@ -150,6 +152,7 @@ func (dev *Device) ControlInterface() *ControlInterface {
 
 	return &ControlInterface{
 		itf:             dev.handle.info.ctrl_if,
+		dev:             dev,
 		BcdUVC:          uint16(dev.handle.info.ctrl_if.bcdUVC),
 		Number:          uint8(dev.handle.info.ctrl_if.bInterfaceNumber),
 		EndpointAddress: uint8(dev.handle.info.ctrl_if.bEndpointAddress),
@@ -252,6 +255,11 @@ func (dev *Device) Close() error {
 	dev.mu.Lock()
 	defer dev.mu.Unlock()
 
+	key := uintptr(unsafe.Pointer(dev.handle))
+	statusMu.Lock()
+	delete(statusHandlers, key)
+	statusMu.Unlock()
+
 	C.uvc_close(dev.handle)
 	dev.handle = nil
 
@@ -295,6 +303,7 @@ func (d *DeviceDescriptor) String() string {
 // VideoControl interface.
 type ControlInterface struct {
 	itf             C.uvc_control_interface_t
+	dev             *Device
 	BcdUVC          uint16
 	Number          uint8
 	EndpointAddress uint8
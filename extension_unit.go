@@ -0,0 +1,82 @@
+package uvc
+
+/*
+#include <libuvc-cgo.h>
+*/
+import "C"
+import "unsafe"
+
+// ExtensionUnit mirrors a VC_EXTENSION_UNIT descriptor discovered on the
+// VideoControl interface: a vendor-defined GUID, the unit ID selector used
+// to address it, and the bmControls bitmap of which selectors it implements.
+type ExtensionUnit struct {
+	dev *Device
+
+	// GUID identifying the vendor extension (guidExtensionCode).
+	GUID [16]byte
+	// UnitID is the bUnitID used as wIndex when addressing this unit.
+	UnitID uint8
+	// NumControls is the number of selectors bmControls advertises.
+	NumControls uint8
+	// Controls is the bmControls bitmap of supported selectors.
+	Controls uint64
+}
+
+// ExtensionUnits walks the VideoControl interface's extension-unit list, as
+// the Linux UVC driver does when it encounters VC_EXTENSION_UNIT entities.
+func (i *ControlInterface) ExtensionUnits() (units []*ExtensionUnit) {
+	for xu := i.itf.extension_units; xu != nil; xu = xu.next {
+		u := &ExtensionUnit{
+			dev:         i.dev,
+			UnitID:      uint8(xu.bUnitID),
+			NumControls: uint8(xu.bNumControls),
+			Controls:    uint64(xu.bmControls),
+		}
+		copy(u.GUID[:], (*[16]byte)(unsafe.Pointer(&xu.guidExtensionCode[0]))[:])
+		units = append(units, u)
+	}
+	return
+}
+
+// Get issues a class-specific GET request (GET_CUR, GET_MIN, GET_MAX,
+// GET_RES, GET_DEF, GET_LEN, or GET_INFO) for the given control selector and
+// reads the result into buf, returning the number of bytes written.
+func (xu *ExtensionUnit) Get(selector uint8, req ControlRequest, buf []byte) (int, error) {
+	xu.dev.mu.RLock()
+	defer xu.dev.mu.RUnlock()
+
+	if xu.dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+	if len(buf) == 0 {
+		return 0, ErrInvalidParam
+	}
+
+	r := C.uvc_get_ctrl(xu.dev.handle, C.uchar(xu.UnitID), C.uchar(selector),
+		unsafe.Pointer(&buf[0]), C.int(len(buf)), C.enum_uvc_req_code(req))
+	if r < 0 {
+		return 0, newError(ErrorType(r))
+	}
+	return int(r), nil
+}
+
+// Set issues a class-specific SET_CUR request for the given control
+// selector, sending buf as the control's data.
+func (xu *ExtensionUnit) Set(selector uint8, buf []byte) error {
+	xu.dev.mu.RLock()
+	defer xu.dev.mu.RUnlock()
+
+	if xu.dev.handle == nil {
+		return ErrDeviceClosed
+	}
+	if len(buf) == 0 {
+		return ErrInvalidParam
+	}
+
+	r := C.uvc_set_ctrl(xu.dev.handle, C.uchar(xu.UnitID), C.uchar(selector),
+		unsafe.Pointer(&buf[0]), C.int(len(buf)))
+	if r < 0 {
+		return newError(ErrorType(r))
+	}
+	return nil
+}
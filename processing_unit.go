@@ -0,0 +1,612 @@
+package uvc
+
+/*
+#include <libuvc-cgo.h>
+*/
+import "C"
+
+// WhiteBalanceComponent is the paired blue/red chroma control backed by the
+// UVC Processing Unit's PU_WHITE_BALANCE_COMPONENT_CONTROL, which packs two
+// wide values into a single class-specific request.
+type WhiteBalanceComponent struct {
+	Blue uint16
+	Red  uint16
+}
+
+func (dev *Device) GetContrast(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_contrast(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetContrast(contrast uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_contrast(dev.handle, C.ushort(contrast))
+	return newError(ErrorType(r))
+}
+
+// GetContrastRange reports the min, max, default, and step size the device
+// advertises for Contrast, via GET_MIN / GET_MAX / GET_DEF / GET_RES.
+func (dev *Device) GetContrastRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetContrast(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetContrast(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetContrast(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetContrast(ReqRes)
+	return
+}
+
+func (dev *Device) GetContrastAuto() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_contrast_auto(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetContrastAuto(auto uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_contrast_auto(dev.handle, C.uchar(auto))
+	return newError(ErrorType(r))
+}
+
+// GetHue and SetHue deal in a signed value, per the UVC spec's
+// PU_HUE_CONTROL, as SetBrightness does, unlike the rest of the Processing
+// Unit's wide controls.
+func (dev *Device) GetHue(req ControlRequest) (int16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.short
+	r := C.uvc_get_hue(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return int16(v), nil
+}
+
+func (dev *Device) SetHue(hue int16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_hue(dev.handle, C.short(hue))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetHueRange() (min, max, def, res int16, err error) {
+	if min, err = dev.GetHue(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetHue(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetHue(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetHue(ReqRes)
+	return
+}
+
+func (dev *Device) GetHueAuto() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_hue_auto(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetHueAuto(auto uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_hue_auto(dev.handle, C.uchar(auto))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetSaturation(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_saturation(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetSaturation(saturation uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_saturation(dev.handle, C.ushort(saturation))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetSaturationRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetSaturation(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetSaturation(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetSaturation(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetSaturation(ReqRes)
+	return
+}
+
+func (dev *Device) GetSharpness(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_sharpness(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetSharpness(sharpness uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_sharpness(dev.handle, C.ushort(sharpness))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetSharpnessRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetSharpness(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetSharpness(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetSharpness(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetSharpness(ReqRes)
+	return
+}
+
+func (dev *Device) GetGamma(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_gamma(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetGamma(gamma uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_gamma(dev.handle, C.ushort(gamma))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetGammaRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetGamma(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetGamma(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetGamma(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetGamma(ReqRes)
+	return
+}
+
+func (dev *Device) GetBacklightCompensation(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_backlight_compensation(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetBacklightCompensation(compensation uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_backlight_compensation(dev.handle, C.ushort(compensation))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetBacklightCompensationRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetBacklightCompensation(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetBacklightCompensation(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetBacklightCompensation(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetBacklightCompensation(ReqRes)
+	return
+}
+
+func (dev *Device) GetGain(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_gain(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetGain(gain uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_gain(dev.handle, C.ushort(gain))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetGainRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetGain(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetGain(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetGain(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetGain(ReqRes)
+	return
+}
+
+func (dev *Device) GetPowerLineFrequency(req ControlRequest) (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_power_line_frequency(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetPowerLineFrequency(frequency uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_power_line_frequency(dev.handle, C.uchar(frequency))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetPowerLineFrequencyRange() (min, max, def, res uint8, err error) {
+	if min, err = dev.GetPowerLineFrequency(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetPowerLineFrequency(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetPowerLineFrequency(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetPowerLineFrequency(ReqRes)
+	return
+}
+
+func (dev *Device) GetWhiteBalanceTemperature(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_white_balance_temperature(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetWhiteBalanceTemperature(temperature uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_white_balance_temperature(dev.handle, C.ushort(temperature))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetWhiteBalanceTemperatureRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetWhiteBalanceTemperature(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetWhiteBalanceTemperature(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetWhiteBalanceTemperature(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetWhiteBalanceTemperature(ReqRes)
+	return
+}
+
+func (dev *Device) GetWhiteBalanceTemperatureAuto() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_white_balance_temperature_auto(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetWhiteBalanceTemperatureAuto(auto uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_white_balance_temperature_auto(dev.handle, C.uchar(auto))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetWhiteBalanceComponent(req ControlRequest) (WhiteBalanceComponent, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return WhiteBalanceComponent{}, ErrDeviceClosed
+	}
+
+	var blue, red C.ushort
+	r := C.uvc_get_white_balance_component(dev.handle, &blue, &red, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return WhiteBalanceComponent{}, err
+	}
+	return WhiteBalanceComponent{Blue: uint16(blue), Red: uint16(red)}, nil
+}
+
+func (dev *Device) SetWhiteBalanceComponent(wbc WhiteBalanceComponent) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_white_balance_component(dev.handle, C.ushort(wbc.Blue), C.ushort(wbc.Red))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetWhiteBalanceComponentRange() (min, max, def, res WhiteBalanceComponent, err error) {
+	if min, err = dev.GetWhiteBalanceComponent(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetWhiteBalanceComponent(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetWhiteBalanceComponent(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetWhiteBalanceComponent(ReqRes)
+	return
+}
+
+func (dev *Device) GetWhiteBalanceComponentAuto() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_white_balance_component_auto(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) SetWhiteBalanceComponentAuto(auto uint8) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_white_balance_component_auto(dev.handle, C.uchar(auto))
+	return newError(ErrorType(r))
+}
+
+// GetAnalogVideoStandard reports the analog video standard (NTSC, PAL, ...)
+// the device detected upstream of digitization. It is GET_CUR only; the
+// Processing Unit does not support setting or ranging this control.
+func (dev *Device) GetAnalogVideoStandard() (uint8, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_analog_video_standard(dev.handle, &v, C.enum_uvc_req_code(ReqCur))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint8(v), nil
+}
+
+func (dev *Device) GetDigitalMultiplier(req ControlRequest) (uint16, error) {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.ushort
+	r := C.uvc_get_digital_multiplier(dev.handle, &v, C.enum_uvc_req_code(req))
+	if err := newError(ErrorType(r)); err != nil {
+		return 0, err
+	}
+	return uint16(v), nil
+}
+
+func (dev *Device) SetDigitalMultiplier(multiplier uint16) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	r := C.uvc_set_digital_multiplier(dev.handle, C.ushort(multiplier))
+	return newError(ErrorType(r))
+}
+
+func (dev *Device) GetDigitalMultiplierRange() (min, max, def, res uint16, err error) {
+	if min, err = dev.GetDigitalMultiplier(ReqMin); err != nil {
+		return
+	}
+	if max, err = dev.GetDigitalMultiplier(ReqMax); err != nil {
+		return
+	}
+	if def, err = dev.GetDigitalMultiplier(ReqDef); err != nil {
+		return
+	}
+	res, err = dev.GetDigitalMultiplier(ReqRes)
+	return
+}
@@ -0,0 +1,146 @@
+package uvc
+
+/*
+#include <libuvc-cgo.h>
+
+extern void goStatusTrampoline(enum uvc_status_class status_class, int event,
+	int selector, int status_attribute, void *data, size_t data_len, void *user_ptr);
+
+static inline void uvc_set_status_callback_cgo(uvc_device_handle_t *devh) {
+	uvc_set_status_callback(devh, (uvc_status_callback_t)goStatusTrampoline, devh);
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// StatusClass identifies which UVC class the status event's entity belongs
+// to (VideoControl, or the Camera/Processing Unit selector sub-ranges that
+// also get their own class so a button/value-change can be routed without
+// decoding the selector itself).
+type StatusClass uint8
+
+const (
+	StatusClassControl  StatusClass = C.UVC_STATUS_CLASS_CONTROL
+	StatusClassCTCamera StatusClass = C.UVC_STATUS_CLASS_CONTROL_CAMERA
+	StatusClassCTProc   StatusClass = C.UVC_STATUS_CLASS_CONTROL_PROCESSING
+)
+
+// StatusAttribute is libuvc's status_attribute parameter: what kind of
+// change a control-class event represents. It is UVC_STATUS_ATTRIBUTE_UNKNOWN
+// for the Camera/Processing Unit classes, which don't carry this distinction.
+type StatusAttribute uint8
+
+const (
+	StatusAttributeValueChange   StatusAttribute = C.UVC_STATUS_ATTRIBUTE_VALUE_CHANGE
+	StatusAttributeInfoChange    StatusAttribute = C.UVC_STATUS_ATTRIBUTE_INFO_CHANGE
+	StatusAttributeFailureChange StatusAttribute = C.UVC_STATUS_ATTRIBUTE_FAILURE_CHANGE
+	StatusAttributeUnknown       StatusAttribute = C.UVC_STATUS_ATTRIBUTE_UNKNOWN
+)
+
+// StatusEvent is a single message delivered on the device's interrupt status
+// endpoint. Class selects how Event and Selector should be interpreted:
+// for StatusClassControl, Selector is the VC control selector and Attribute
+// says what changed about it; for the Camera/Processing Unit classes, Event
+// is libuvc's raw originator/button byte and Attribute is always Unknown.
+// libuvc's uvc_status_callback_t has no separate originator parameter, so a
+// button's originating entity is encoded in Event, not a distinct field.
+type StatusEvent struct {
+	Class     StatusClass
+	Event     uint8
+	Selector  uint8
+	Attribute StatusAttribute
+	Data      []byte
+}
+
+var (
+	statusMu       sync.Mutex
+	statusHandlers = map[uintptr]func(StatusEvent){}
+)
+
+//export goStatusTrampoline
+func goStatusTrampoline(statusClass C.enum_uvc_status_class, event, selector, attribute C.int, data unsafe.Pointer, dataLen C.size_t, userPtr unsafe.Pointer) {
+	key := uintptr(userPtr)
+
+	statusMu.Lock()
+	handler := statusHandlers[key]
+	statusMu.Unlock()
+
+	if handler == nil {
+		return
+	}
+
+	buf := make([]byte, int(dataLen))
+	if dataLen > 0 {
+		copy(buf, (*[1 << 30]byte)(data)[:int(dataLen):int(dataLen)])
+	}
+
+	ev := StatusEvent{
+		Class:     StatusClass(statusClass),
+		Event:     uint8(event),
+		Selector:  uint8(selector),
+		Attribute: StatusAttribute(attribute),
+		Data:      buf,
+	}
+
+	go handler(ev)
+}
+
+// StartStatusCallback registers fn to be called, from its own goroutine, for
+// every StatusEvent the device's interrupt status endpoint delivers. It
+// wraps libuvc's uvc_set_status_callback; on the libusb IRQ thread the
+// trampoline only copies the payload and starts a goroutine for fn, so fn is
+// always free to block or call back into this package.
+func (dev *Device) StartStatusCallback(fn func(StatusEvent)) error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	key := uintptr(unsafe.Pointer(dev.handle))
+
+	statusMu.Lock()
+	statusHandlers[key] = fn
+	statusMu.Unlock()
+
+	C.uvc_set_status_callback_cgo(dev.handle)
+	return nil
+}
+
+// StartStatusChannel is a channel-based convenience wrapper around
+// StartStatusCallback for callers that would rather range over events than
+// supply a callback.
+func (dev *Device) StartStatusChannel(buffer int) (<-chan StatusEvent, error) {
+	ch := make(chan StatusEvent, buffer)
+	if err := dev.StartStatusCallback(func(ev StatusEvent) { ch <- ev }); err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// StopStatusCallback tears down the status callback registered with
+// StartStatusCallback or StartStatusChannel. Close also removes the
+// registered handler, so callers don't strictly need to call this on their
+// own, but doing so before Close
+// avoids a window where events could still be dispatched during teardown.
+func (dev *Device) StopStatusCallback() error {
+	dev.mu.RLock()
+	defer dev.mu.RUnlock()
+
+	if dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	C.uvc_set_status_callback(dev.handle, nil, nil)
+
+	key := uintptr(unsafe.Pointer(dev.handle))
+	statusMu.Lock()
+	delete(statusHandlers, key)
+	statusMu.Unlock()
+
+	return nil
+}
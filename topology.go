@@ -0,0 +1,162 @@
+package uvc
+
+/*
+#include <libuvc-cgo.h>
+*/
+import "C"
+import "unsafe"
+
+// Entity is any node in the VideoControl topology graph (terminal, unit) the
+// Linux UVC driver walks via uvc_entity_by_reference.
+type Entity interface {
+	EntityID() uint8
+}
+
+// InputTerminal is a VC_INPUT_TERMINAL entity that is not a camera sensor
+// (e.g. a composite or S-Video input).
+type InputTerminal struct {
+	ID           uint8
+	TerminalType uint16
+}
+
+func (t *InputTerminal) EntityID() uint8 { return t.ID }
+
+// OutputTerminal is a VC_OUTPUT_TERMINAL entity, linked back to its source.
+type OutputTerminal struct {
+	ID       uint8
+	SourceID uint8
+}
+
+func (t *OutputTerminal) EntityID() uint8 { return t.ID }
+
+// CameraTerminal is the VC_INPUT_TERMINAL entity whose wTerminalType is
+// ITT_CAMERA, exposing the Camera Terminal controls in camera_terminal.go.
+type CameraTerminal struct {
+	ID                      uint8
+	ObjectiveFocalLengthMin uint16
+	ObjectiveFocalLengthMax uint16
+	OcularFocalLength       uint16
+	Controls                uint64
+}
+
+func (t *CameraTerminal) EntityID() uint8 { return t.ID }
+
+// ProcessingUnit is a VC_PROCESSING_UNIT entity, linked to its source.
+type ProcessingUnit struct {
+	ID            uint8
+	SourceID      uint8
+	MaxMultiplier uint16
+	Controls      uint64
+}
+
+func (p *ProcessingUnit) EntityID() uint8 { return p.ID }
+
+// SelectorUnit is a VC_SELECTOR_UNIT entity that multiplexes one of several
+// input pins (e.g. a dual-lens or RGB+IR sensor module) onto its output.
+type SelectorUnit struct {
+	dev *Device
+
+	ID uint8
+	// InputPins lists the source entity IDs feeding this selector, in
+	// baSourceID order: InputPins[n-1] feeds SU_INPUT_SELECT_CONTROL pin n,
+	// since pin numbers are 1-based.
+	InputPins []uint8
+}
+
+func (s *SelectorUnit) EntityID() uint8 { return s.ID }
+
+// SetInput selects which input pin is routed to this Selector Unit's output,
+// via SU_INPUT_SELECT_CONTROL. Pin numbers are 1-based.
+func (su *SelectorUnit) SetInput(pin uint8) error {
+	su.dev.mu.RLock()
+	defer su.dev.mu.RUnlock()
+
+	if su.dev.handle == nil {
+		return ErrDeviceClosed
+	}
+
+	v := C.uchar(pin)
+	r := C.uvc_set_ctrl(su.dev.handle, C.uchar(su.ID), C.uchar(C.UVC_SU_INPUT_SELECT_CONTROL),
+		unsafe.Pointer(&v), 1)
+	if r < 0 {
+		return newError(ErrorType(r))
+	}
+	return nil
+}
+
+// GetInput reports the currently selected input pin.
+func (su *SelectorUnit) GetInput() (uint8, error) {
+	su.dev.mu.RLock()
+	defer su.dev.mu.RUnlock()
+
+	if su.dev.handle == nil {
+		return 0, ErrDeviceClosed
+	}
+
+	var v C.uchar
+	r := C.uvc_get_ctrl(su.dev.handle, C.uchar(su.ID), C.uchar(C.UVC_SU_INPUT_SELECT_CONTROL),
+		unsafe.Pointer(&v), 1, C.enum_uvc_req_code(ReqCur))
+	if r < 0 {
+		return 0, newError(ErrorType(r))
+	}
+	return uint8(v), nil
+}
+
+func (xu *ExtensionUnit) EntityID() uint8 { return xu.UnitID }
+
+// Entities walks the VideoControl interface's terminal and unit lists, the
+// way the Linux driver's uvc_entity_by_reference switches over
+// TT_STREAMING, VC_PROCESSING_UNIT, VC_SELECTOR_UNIT, and VC_EXTENSION_UNIT.
+func (i *ControlInterface) Entities() (entities []Entity) {
+	for it := i.itf.input_terminals; it != nil; it = it.next {
+		if uint16(it.wTerminalType) == uint16(C.UVC_ITT_CAMERA) {
+			entities = append(entities, &CameraTerminal{
+				ID:                      uint8(it.bTerminalID),
+				ObjectiveFocalLengthMin: uint16(it.wObjectiveFocalLengthMin),
+				ObjectiveFocalLengthMax: uint16(it.wObjectiveFocalLengthMax),
+				OcularFocalLength:       uint16(it.wOcularFocalLength),
+				Controls:                uint64(it.bmControls),
+			})
+			continue
+		}
+		entities = append(entities, &InputTerminal{
+			ID:           uint8(it.bTerminalID),
+			TerminalType: uint16(it.wTerminalType),
+		})
+	}
+
+	for ot := i.itf.output_terminals; ot != nil; ot = ot.next {
+		entities = append(entities, &OutputTerminal{
+			ID:       uint8(ot.bTerminalID),
+			SourceID: uint8(ot.bSourceID),
+		})
+	}
+
+	for pu := i.itf.processing_units; pu != nil; pu = pu.next {
+		entities = append(entities, &ProcessingUnit{
+			ID:            uint8(pu.bUnitID),
+			SourceID:      uint8(pu.bSourceID),
+			MaxMultiplier: uint16(pu.wMaxMultiplier),
+			Controls:      uint64(pu.bmControls),
+		})
+	}
+
+	for su := i.itf.selector_units; su != nil; su = su.next {
+		s := &SelectorUnit{
+			dev: i.dev,
+			ID:  uint8(su.bUnitID),
+		}
+		base := uintptr(unsafe.Pointer(&su.baSourceID[0]))
+		for n := 0; n < int(su.bNrInPins); n++ {
+			pin := *(*C.uchar)(unsafe.Pointer(base + uintptr(n)*unsafe.Sizeof(su.baSourceID[0])))
+			s.InputPins = append(s.InputPins, uint8(pin))
+		}
+		entities = append(entities, s)
+	}
+
+	for _, xu := range i.ExtensionUnits() {
+		entities = append(entities, xu)
+	}
+
+	return
+}